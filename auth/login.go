@@ -6,30 +6,58 @@ import (
 	"time"
 
 	"github.com/automation-poc/browser-automation/config"
+	"github.com/automation-poc/browser-automation/handoff"
+	"github.com/automation-poc/browser-automation/hooks"
 	"github.com/automation-poc/browser-automation/logger"
 	"github.com/automation-poc/browser-automation/stealth"
 	"github.com/go-rod/rod"
 )
 
 type LoginHandler struct {
-	cfg    *config.Config
-	log    *logger.Logger
-	mouse  *stealth.MouseController
-	typing *stealth.TypingSimulator
-	timing *stealth.TimingController
+	cfg       *config.Config
+	log       *logger.Logger
+	mouse     *stealth.MouseController
+	typing    *stealth.TypingSimulator
+	timing    *stealth.TimingController
+	hooks     *hooks.HookTable
+	handoff   *handoff.Handler
+	sessionID string
 }
 
-func NewLoginHandler(cfg *config.Config, log *logger.Logger) *LoginHandler {
+// NewLoginHandler wires up a login flow. handoffHandler may be nil, in which
+// case a detected CAPTCHA/2FA checkpoint fails the run immediately instead
+// of pausing for a human.
+func NewLoginHandler(cfg *config.Config, log *logger.Logger, hookTable *hooks.HookTable, handoffHandler *handoff.Handler, sessionID string) *LoginHandler {
+	if hookTable == nil {
+		hookTable = hooks.Default
+	}
+
 	return &LoginHandler{
-		cfg:    cfg,
-		log:    log,
-		mouse:  stealth.NewMouseController(&cfg.Stealth.MouseMovement),
-		typing: stealth.NewTypingSimulator(&cfg.Timing),
-		timing: stealth.NewTimingController(&cfg.Timing),
+		cfg:       cfg,
+		log:       log,
+		mouse:     stealth.NewMouseController(&cfg.Stealth.MouseMovement),
+		typing:    stealth.NewTypingSimulator(&cfg.Timing),
+		timing:    stealth.NewTimingController(&cfg.Timing),
+		hooks:     hookTable,
+		handoff:   handoffHandler,
+		sessionID: sessionID,
 	}
 }
 
 func (lh *LoginHandler) Login(ctx context.Context, page *rod.Page, email, password string) error {
+	loginCtx := hooks.LoginCtx{Page: page, Email: email}
+
+	result, skip, err := lh.hooks.Fire(hooks.PreLogin, loginCtx)
+	if err != nil {
+		return fmt.Errorf("pre_login hook: %w", err)
+	}
+	loginCtx = result.(hooks.LoginCtx)
+	if skip {
+		lh.log.Info("Login skipped by pre_login hook")
+		return nil
+	}
+	email = loginCtx.Email
+
 	lh.log.Info("Navigating to login page")
 
 	if err := page.Navigate(lh.cfg.Auth.LoginURL); err != nil {
@@ -42,7 +70,7 @@ func (lh *LoginHandler) Login(ctx context.Context, page *rod.Page, email, passwo
 
 	time.Sleep(lh.timing.PageLoadDelay())
 
-	if err := lh.detectSecurityCheckpoints(page); err != nil {
+	if err := lh.detectSecurityCheckpoints(ctx, page); err != nil {
 		return err
 	}
 
@@ -108,20 +136,26 @@ func (lh *LoginHandler) Login(ctx context.Context, page *rod.Page, email, passwo
 		return err
 	}
 
-	if err := lh.detectSecurityCheckpoints(page); err != nil {
+	if err := lh.detectSecurityCheckpoints(ctx, page); err != nil {
 		return err
 	}
 
 	lh.log.Info("Login successful")
+
+	if _, _, err := lh.hooks.Fire(hooks.PostLogin, hooks.LoginCtx{Page: page, Email: email}); err != nil {
+		return fmt.Errorf("post_login hook: %w", err)
+	}
+
 	return nil
 }
 
-func (lh *LoginHandler) detectSecurityCheckpoints(page *rod.Page) error {
+func (lh *LoginHandler) detectSecurityCheckpoints(ctx context.Context, page *rod.Page) error {
 	if captchaSelector, exists := lh.cfg.Auth.Selectors["captcha_detected"]; exists {
 		has, _, err := page.Has(captchaSelector)
 		if err == nil && has {
 			lh.log.Warn("CAPTCHA detected - manual intervention required")
-			return fmt.Errorf("CAPTCHA challenge detected")
+			lh.fireCaptchaDetected(page)
+			return lh.awaitHandoff(ctx, page, "captcha", captchaSelector)
 		}
 	}
 
@@ -129,13 +163,30 @@ func (lh *LoginHandler) detectSecurityCheckpoints(page *rod.Page) error {
 		has, _, err := page.Has(twoFASelector)
 		if err == nil && has {
 			lh.log.Warn("2FA prompt detected - manual intervention required")
-			return fmt.Errorf("two-factor authentication required")
+			lh.fireCaptchaDetected(page)
+			return lh.awaitHandoff(ctx, page, "2fa", twoFASelector)
 		}
 	}
 
 	return nil
 }
 
+// awaitHandoff pauses on a detected challenge and waits for a human to
+// clear it. With no handoff handler configured it fails fast, as before.
+func (lh *LoginHandler) awaitHandoff(ctx context.Context, page *rod.Page, challengeType, challengeSelector string) error {
+	if lh.handoff == nil {
+		return fmt.Errorf("%s challenge detected", challengeType)
+	}
+
+	return lh.handoff.Trigger(ctx, page, lh.sessionID, challengeType, challengeSelector)
+}
+
+func (lh *LoginHandler) fireCaptchaDetected(page *rod.Page) {
+	if _, _, err := lh.hooks.Fire(hooks.CaptchaDetected, hooks.LoginCtx{Page: page}); err != nil {
+		lh.log.Warnf("captcha_detected hook: %v", err)
+	}
+}
+
 func (lh *LoginHandler) detectLoginFailure(page *rod.Page) error {
 	if errorSelector, exists := lh.cfg.Auth.Selectors["login_failed"]; exists {
 		has, elem, err := page.Has(errorSelector)
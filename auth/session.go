@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/automation-poc/browser-automation/config"
+	"github.com/automation-poc/browser-automation/handoff"
+	"github.com/automation-poc/browser-automation/hooks"
 	"github.com/automation-poc/browser-automation/logger"
 	"github.com/automation-poc/browser-automation/storage"
 	"github.com/go-rod/rod"
@@ -18,12 +20,12 @@ type Service struct {
 	loginHandler *LoginHandler
 }
 
-func NewService(cfg *config.Config, db *storage.Database, log *logger.Logger) *Service {
+func NewService(cfg *config.Config, db *storage.Database, log *logger.Logger, hookTable *hooks.HookTable, handoffHandler *handoff.Handler, sessionID string) *Service {
 	return &Service{
 		cfg:          cfg,
 		db:           db,
 		log:          log,
-		loginHandler: NewLoginHandler(cfg, log),
+		loginHandler: NewLoginHandler(cfg, log, hookTable, handoffHandler, sessionID),
 	}
 }
 
@@ -49,6 +51,13 @@ func (s *Service) Login(ctx context.Context, page *rod.Page) error {
 	return s.loginHandler.Login(ctx, page, email, password)
 }
 
+// IsLoggedIn reports whether page still shows an authenticated session,
+// without navigating anywhere. Used by maintenance.SessionRefreshTask to
+// decide whether a proactive re-login is needed.
+func (s *Service) IsLoggedIn(page *rod.Page) bool {
+	return s.loginHandler.IsLoggedIn(page)
+}
+
 func (s *Service) SaveSession(page *rod.Page) error {
 	s.log.Debug("Saving session cookies")
 
@@ -12,9 +12,13 @@ import (
 	"github.com/automation-poc/browser-automation/auth"
 	"github.com/automation-poc/browser-automation/config"
 	"github.com/automation-poc/browser-automation/connections"
+	"github.com/automation-poc/browser-automation/handoff"
+	"github.com/automation-poc/browser-automation/hooks"
 	"github.com/automation-poc/browser-automation/logger"
+	"github.com/automation-poc/browser-automation/maintenance"
 	"github.com/automation-poc/browser-automation/messaging"
 	"github.com/automation-poc/browser-automation/search"
+	"github.com/automation-poc/browser-automation/selectorhealth"
 	"github.com/automation-poc/browser-automation/storage"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
@@ -24,9 +28,15 @@ var (
 	configPath = flag.String("config", "config.yaml", "Path to configuration file")
 	demoMode   = flag.Bool("demo", false, "Run in demo mode (prints actions without executing)")
 	safeMode   = flag.Bool("safe", true, "Enable safe mode (prevents real-world execution)")
+	pluginDir  = flag.String("plugin-dir", "", "Directory of .so hook plugins to load at startup")
 )
 
 func main() {
+	if len(os.Args) >= 4 && os.Args[1] == "handoff" && os.Args[2] == "complete" {
+		runHandoffComplete(os.Args[3])
+		return
+	}
+
 	flag.Parse()
 
 	log := logger.New()
@@ -52,6 +62,12 @@ func main() {
 		log.Fatalf("Configuration validation failed: %v", err)
 	}
 
+	if *pluginDir != "" {
+		if err := hooks.LoadPlugins(*pluginDir, hooks.Default); err != nil {
+			log.Fatalf("Failed to load hook plugins: %v", err)
+		}
+	}
+
 	db, err := storage.NewDatabase(cfg.Database.Path)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -77,6 +93,23 @@ func main() {
 	log.Info("Automation completed successfully")
 }
 
+// runHandoffComplete implements `automation handoff complete <session-id>`,
+// signalling a paused run over the handoff IPC socket that a human has
+// cleared the challenge.
+func runHandoffComplete(sessionID string) {
+	socketPath := os.Getenv("HANDOFF_SOCKET_PATH")
+	if socketPath == "" {
+		socketPath = config.DefaultConfig().Handoff.IPCSocketPath
+	}
+
+	if err := handoff.SendComplete(socketPath, sessionID); err != nil {
+		fmt.Fprintf(os.Stderr, "handoff complete failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Handoff %s marked complete\n", sessionID)
+}
+
 func run(ctx context.Context, cfg *config.Config, db *storage.Database, log *logger.Logger) error {
 	if cfg.DemoMode {
 		return runDemo(ctx, cfg, db, log)
@@ -88,7 +121,14 @@ func run(ctx context.Context, cfg *config.Config, db *storage.Database, log *log
 	}
 	defer browser.Close()
 
-	authService := auth.NewService(cfg, db, log)
+	sessionID := handoff.NewSessionID()
+	handoffHandler, err := handoff.NewHandler(&cfg.Handoff, db, log, handoff.NotifiersFromConfig(&cfg.Handoff)...)
+	if err != nil {
+		return fmt.Errorf("failed to start handoff handler: %w", err)
+	}
+	defer handoffHandler.Close()
+
+	authService := auth.NewService(cfg, db, log, hooks.Default, handoffHandler, sessionID)
 	sessionValid, err := authService.LoadSession(page)
 	if err != nil {
 		log.Warnf("Failed to load session: %v", err)
@@ -113,9 +153,17 @@ func run(ctx context.Context, cfg *config.Config, db *storage.Database, log *log
 
 	log.Infof("Found %d profiles to process", len(profiles))
 
-	connectionService := connections.NewService(cfg, db, log)
+	connectionService := connections.NewService(cfg, db, log, hooks.Default, handoffHandler, sessionID)
 	messageService := messaging.NewService(cfg, db, log)
 
+	maintenanceRunner := maintenance.NewRunner(log,
+		maintenance.NewSessionRefreshTask(authService, page, log, 15*time.Minute),
+		maintenance.NewRateLimitRolloverTask(db, log, time.Hour, 30*24*time.Hour),
+		maintenance.NewStaleProfileGCTask(db, log, 6*time.Hour, 90*24*time.Hour),
+		maintenance.NewSelectorHealthTask(selectorhealth.Default, log, 10*time.Minute),
+	)
+	maintenanceRunner.Start(ctx)
+
 	for _, profile := range profiles {
 		select {
 		case <-ctx.Done():
@@ -16,6 +16,7 @@ type Config struct {
 	Timing   TimingConfig   `yaml:"timing"`
 	Stealth  StealthConfig  `yaml:"stealth"`
 	Database DatabaseConfig `yaml:"database"`
+	Handoff  HandoffConfig  `yaml:"handoff"`
 	DemoMode bool           `yaml:"demo_mode"`
 	SafeMode bool           `yaml:"safe_mode"`
 }
@@ -27,26 +28,26 @@ type BrowserConfig struct {
 }
 
 type AuthConfig struct {
-	LoginURL        string            `yaml:"login_url"`
-	EmailSelector   string            `yaml:"email_selector"`
-	PasswordSelector string           `yaml:"password_selector"`
-	SubmitSelector  string            `yaml:"submit_selector"`
-	Selectors       map[string]string `yaml:"selectors"`
+	LoginURL         string            `yaml:"login_url"`
+	EmailSelector    string            `yaml:"email_selector"`
+	PasswordSelector string            `yaml:"password_selector"`
+	SubmitSelector   string            `yaml:"submit_selector"`
+	Selectors        map[string]string `yaml:"selectors"`
 }
 
 type SearchConfig struct {
-	BaseURL       string            `yaml:"base_url"`
-	Query         string            `yaml:"query"`
-	Filters       map[string]string `yaml:"filters"`
-	MaxPages      int               `yaml:"max_pages"`
-	ResultsPerPage int              `yaml:"results_per_page"`
-	Selectors     map[string]string `yaml:"selectors"`
+	BaseURL        string            `yaml:"base_url"`
+	Query          string            `yaml:"query"`
+	Filters        map[string]string `yaml:"filters"`
+	MaxPages       int               `yaml:"max_pages"`
+	ResultsPerPage int               `yaml:"results_per_page"`
+	Selectors      map[string]string `yaml:"selectors"`
 }
 
 type LimitsConfig struct {
-	DailyConnections int `yaml:"daily_connections"`
+	DailyConnections  int `yaml:"daily_connections"`
 	HourlyConnections int `yaml:"hourly_connections"`
-	DailyMessages    int `yaml:"daily_messages"`
+	DailyMessages     int `yaml:"daily_messages"`
 }
 
 type TimingConfig struct {
@@ -84,6 +85,22 @@ type DatabaseConfig struct {
 	Path string `yaml:"path"`
 }
 
+type HandoffConfig struct {
+	IPCSocketPath   string        `yaml:"ipc_socket_path"`
+	WaitTimeout     time.Duration `yaml:"wait_timeout"`
+	PollInterval    time.Duration `yaml:"poll_interval"`
+	SMTP            SMTPConfig    `yaml:"smtp"`
+	SlackWebhookURL string        `yaml:"slack_webhook_url"`
+	WebhookURL      string        `yaml:"webhook_url"`
+}
+
+type SMTPConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -193,12 +210,12 @@ func DefaultConfig() *Config {
 			MaxPages:       5,
 			ResultsPerPage: 10,
 			Selectors: map[string]string{
-				"profile_card":      ".profile-card",
-				"profile_name":      ".profile-name",
-				"profile_title":     ".profile-title",
-				"profile_company":   ".profile-company",
-				"profile_link":      ".profile-link",
-				"next_page_button":  ".pagination-next",
+				"profile_card":     ".profile-card",
+				"profile_name":     ".profile-name",
+				"profile_title":    ".profile-title",
+				"profile_company":  ".profile-company",
+				"profile_link":     ".profile-link",
+				"next_page_button": ".pagination-next",
 			},
 		},
 		Limits: LimitsConfig{
@@ -239,6 +256,11 @@ func DefaultConfig() *Config {
 		Database: DatabaseConfig{
 			Path: "automation.db",
 		},
+		Handoff: HandoffConfig{
+			IPCSocketPath: "/tmp/automation-handoff.sock",
+			WaitTimeout:   30 * time.Minute,
+			PollInterval:  5 * time.Second,
+		},
 		SafeMode: true,
 		DemoMode: false,
 	}
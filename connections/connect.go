@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/automation-poc/browser-automation/config"
+	"github.com/automation-poc/browser-automation/handoff"
+	"github.com/automation-poc/browser-automation/hooks"
 	"github.com/automation-poc/browser-automation/logger"
 	"github.com/automation-poc/browser-automation/search"
+	"github.com/automation-poc/browser-automation/selectorhealth"
 	"github.com/automation-poc/browser-automation/stealth"
 	"github.com/automation-poc/browser-automation/storage"
 	"github.com/go-rod/rod"
@@ -22,9 +25,19 @@ type Service struct {
 	timing      *stealth.TimingController
 	fingerprint *stealth.FingerprintManager
 	limiter     *RateLimiter
+	hooks       *hooks.HookTable
+	handoff     *handoff.Handler
+	sessionID   string
 }
 
-func NewService(cfg *config.Config, db *storage.Database, log *logger.Logger) *Service {
+// NewService wires up the connection-request flow. handoffHandler may be
+// nil, in which case a mid-flow security interstitial fails the request
+// instead of pausing for a human.
+func NewService(cfg *config.Config, db *storage.Database, log *logger.Logger, hookTable *hooks.HookTable, handoffHandler *handoff.Handler, sessionID string) *Service {
+	if hookTable == nil {
+		hookTable = hooks.Default
+	}
+
 	return &Service{
 		cfg:         cfg,
 		db:          db,
@@ -34,6 +47,9 @@ func NewService(cfg *config.Config, db *storage.Database, log *logger.Logger) *S
 		timing:      stealth.NewTimingController(&cfg.Timing),
 		fingerprint: stealth.NewFingerprintManager(&cfg.Stealth),
 		limiter:     NewRateLimiter(cfg, db, log),
+		hooks:       hookTable,
+		handoff:     handoffHandler,
+		sessionID:   sessionID,
 	}
 }
 
@@ -44,6 +60,9 @@ func (s *Service) SendConnectionRequest(ctx context.Context, page *rod.Page, pro
 	}
 
 	if !s.limiter.CanSendConnection() {
+		if _, _, err := s.hooks.Fire(hooks.RateLimited, hooks.ConnectionCtx{ProfileID: profile.ID, Name: profile.Name, Page: page}); err != nil {
+			s.log.Warnf("rate_limited hook: %v", err)
+		}
 		return fmt.Errorf("rate limit reached")
 	}
 
@@ -59,6 +78,10 @@ func (s *Service) SendConnectionRequest(ctx context.Context, page *rod.Page, pro
 
 	time.Sleep(s.timing.PageLoadDelay())
 
+	if err := s.detectSecurityCheckpoint(ctx, page); err != nil {
+		return err
+	}
+
 	if err := s.fingerprint.SimulateReading(page, 3*time.Second); err != nil {
 		s.log.Warnf("Failed to simulate reading: %v", err)
 	}
@@ -68,6 +91,17 @@ func (s *Service) SendConnectionRequest(ctx context.Context, page *rod.Page, pro
 		return fmt.Errorf("failed to find connect button: %w", err)
 	}
 
+	connCtx := hooks.ConnectionCtx{ProfileID: profile.ID, Name: profile.Name, Page: page}
+	result, skip, err := s.hooks.Fire(hooks.PreConnectClick, connCtx)
+	if err != nil {
+		return fmt.Errorf("pre_connect_click hook: %w", err)
+	}
+	connCtx = result.(hooks.ConnectionCtx)
+	if skip {
+		s.log.Infof("Connection to %s skipped by pre_connect_click hook", profile.Name)
+		return nil
+	}
+
 	if err := s.mouse.HoverElement(page, connectButton); err != nil {
 		s.log.Warnf("Failed to hover connect button: %v", err)
 	}
@@ -82,8 +116,21 @@ func (s *Service) SendConnectionRequest(ctx context.Context, page *rod.Page, pro
 
 	message := s.generatePersonalizedMessage(profile)
 
-	if err := s.addConnectionNote(page, message); err != nil {
-		s.log.Warnf("Failed to add connection note: %v", err)
+	msgCtx := hooks.ConnectionCtx{ProfileID: profile.ID, Name: profile.Name, Message: message, Page: page}
+	result, skip, err = s.hooks.Fire(hooks.PreMessageSend, msgCtx)
+	if err != nil {
+		return fmt.Errorf("pre_message_send hook: %w", err)
+	}
+	msgCtx = result.(hooks.ConnectionCtx)
+	message = msgCtx.Message
+	if !skip {
+		if err := s.addConnectionNote(page, message); err != nil {
+			s.log.Warnf("Failed to add connection note: %v", err)
+		}
+
+		if _, _, err := s.hooks.Fire(hooks.PostMessageSent, msgCtx); err != nil {
+			s.log.Warnf("post_message_sent hook: %v", err)
+		}
 	}
 
 	if err := s.submitConnectionRequest(page); err != nil {
@@ -108,11 +155,51 @@ func (s *Service) SendConnectionRequest(ctx context.Context, page *rod.Page, pro
 		s.log.Warnf("Failed to increment connection count: %v", err)
 	}
 
+	if err := s.db.IncrementHourlyConnectionCount(); err != nil {
+		s.log.Warnf("Failed to increment hourly connection count: %v", err)
+	}
+
+	if _, _, err := s.hooks.Fire(hooks.PostConnectionSent, connCtx); err != nil {
+		s.log.Warnf("post_connection_sent hook: %v", err)
+	}
+
 	s.log.Infof("Connection request sent successfully to %s", profile.Name)
 
 	return nil
 }
 
+// detectSecurityCheckpoint checks for the same interstitials auth.LoginHandler
+// looks for, so a mid-flow CAPTCHA/2FA prompt pauses for a human instead of
+// losing the in-progress connection request.
+func (s *Service) detectSecurityCheckpoint(ctx context.Context, page *rod.Page) error {
+	checkpoints := []struct{ selectorKey, challengeType string }{
+		{"captcha_detected", "captcha"},
+		{"2fa_detected", "2fa"},
+	}
+
+	for _, cp := range checkpoints {
+		selector, exists := s.cfg.Auth.Selectors[cp.selectorKey]
+		if !exists {
+			continue
+		}
+
+		has, _, err := page.Has(selector)
+		if err != nil || !has {
+			continue
+		}
+
+		s.log.Warnf("%s challenge detected mid-connection-flow - manual intervention required", cp.challengeType)
+
+		if s.handoff == nil {
+			return fmt.Errorf("%s challenge detected", cp.challengeType)
+		}
+
+		return s.handoff.Trigger(ctx, page, s.sessionID, cp.challengeType, selector)
+	}
+
+	return nil
+}
+
 func (s *Service) findConnectButton(page *rod.Page) (*rod.Element, error) {
 	selectors := []string{
 		"button:contains('Connect')",
@@ -123,6 +210,9 @@ func (s *Service) findConnectButton(page *rod.Page) (*rod.Element, error) {
 
 	for _, selector := range selectors {
 		has, elem, err := page.Has(selector)
+		if err == nil {
+			selectorhealth.Default.Record(selector, has)
+		}
 		if err == nil && has {
 			return elem, nil
 		}
@@ -34,6 +34,17 @@ func (rl *RateLimiter) CanSendConnection() bool {
 		return false
 	}
 
+	hourlyStats, err := rl.db.GetCurrentHourStats()
+	if err != nil {
+		rl.log.Errorf("Failed to get hourly stats: %v", err)
+		return false
+	}
+
+	if hourlyStats.ConnectionsSent >= rl.cfg.Limits.HourlyConnections {
+		rl.log.Warnf("Hourly connection limit reached: %d/%d", hourlyStats.ConnectionsSent, rl.cfg.Limits.HourlyConnections)
+		return false
+	}
+
 	if !rl.isWithinBusinessHours() {
 		rl.log.Debug("Outside business hours")
 		return false
@@ -0,0 +1,168 @@
+// Package handoff pauses the automation pipeline when a CAPTCHA or 2FA
+// challenge appears, notifies an operator through one or more pluggable
+// backends, and blocks until the challenge is resolved.
+package handoff
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/automation-poc/browser-automation/config"
+	"github.com/automation-poc/browser-automation/logger"
+	"github.com/automation-poc/browser-automation/storage"
+	"github.com/go-rod/rod"
+)
+
+// NewSessionID generates an identifier a run can use to tag its handoffs so
+// a crash mid-handoff can be matched back up on resume.
+func NewSessionID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// ChallengeEvent describes a single security checkpoint hit during a run.
+type ChallengeEvent struct {
+	SessionID     string
+	ChallengeType string
+	PageURL       string
+	Screenshot    []byte
+	DetectedAt    time.Time
+}
+
+// Notifier delivers a ChallengeEvent to a human operator.
+type Notifier interface {
+	Notify(ctx context.Context, event ChallengeEvent) error
+}
+
+// Handler pauses a run on a detected challenge, fans the event out to every
+// registered Notifier, and resumes once the challenge is cleared.
+type Handler struct {
+	cfg       *config.HandoffConfig
+	db        *storage.Database
+	log       *logger.Logger
+	notifiers []Notifier
+	ipc       *ipcServer
+}
+
+// NewHandler builds a Handler with the given notifiers and starts listening
+// on the configured IPC socket for `automation handoff complete <session>`.
+func NewHandler(cfg *config.HandoffConfig, db *storage.Database, log *logger.Logger, notifiers ...Notifier) (*Handler, error) {
+	h := &Handler{
+		cfg:       cfg,
+		db:        db,
+		log:       log,
+		notifiers: notifiers,
+	}
+
+	if cfg.IPCSocketPath != "" {
+		srv, err := newIPCServer(cfg.IPCSocketPath, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start handoff IPC server: %w", err)
+		}
+		h.ipc = srv
+	}
+
+	return h, nil
+}
+
+// Close stops the background IPC listener, if one is running.
+func (h *Handler) Close() error {
+	if h.ipc == nil {
+		return nil
+	}
+	return h.ipc.Close()
+}
+
+// Trigger persists the pending handoff, notifies every backend, and blocks
+// until the challenge disappears from the page, a completion signal arrives
+// over IPC, or the configured timeout elapses.
+func (h *Handler) Trigger(ctx context.Context, page *rod.Page, sessionID, challengeType, challengeSelector string) error {
+	event := ChallengeEvent{
+		SessionID:     sessionID,
+		ChallengeType: challengeType,
+		PageURL:       page.MustInfo().URL,
+		DetectedAt:    time.Now(),
+	}
+
+	if shot, err := page.Screenshot(false, nil); err == nil {
+		event.Screenshot = shot
+	} else {
+		h.log.Warnf("Failed to capture handoff screenshot: %v", err)
+	}
+
+	if err := h.db.SavePendingHandoff(&storage.PendingHandoff{
+		SessionID:     sessionID,
+		ChallengeType: challengeType,
+		PageURL:       event.PageURL,
+	}); err != nil {
+		h.log.Warnf("Failed to persist pending handoff: %v", err)
+	}
+
+	for _, notifier := range h.notifiers {
+		if err := notifier.Notify(ctx, event); err != nil {
+			h.log.Warnf("Handoff notifier failed: %v", err)
+		}
+	}
+
+	h.log.Warnf("Paused for human handoff (%s), waiting up to %s for resolution", challengeType, h.cfg.WaitTimeout)
+
+	resolved := h.wait(ctx, page, sessionID, challengeSelector)
+
+	if resolved {
+		if err := h.db.ResolvePendingHandoff(sessionID); err != nil {
+			h.log.Warnf("Failed to clear pending handoff: %v", err)
+		}
+		h.log.Info("Handoff resolved, resuming automation")
+		return nil
+	}
+
+	return fmt.Errorf("handoff for session %s timed out waiting for resolution", sessionID)
+}
+
+// wait blocks until whichever of the three completion signals fires first:
+// the challenge element disappearing from the DOM, an operator completion
+// signal over IPC, or the wait timeout.
+func (h *Handler) wait(ctx context.Context, page *rod.Page, sessionID, challengeSelector string) bool {
+	deadline := time.NewTimer(h.cfg.WaitTimeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(h.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var completions <-chan string
+	if h.ipc != nil {
+		completions = h.ipc.completions
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return false
+		case completedSession := <-completions:
+			if completedSession == sessionID {
+				return true
+			}
+		case <-ticker.C:
+			if challengeSelector == "" {
+				continue
+			}
+			has, _, err := page.Has(challengeSelector)
+			if err == nil && !has {
+				return true
+			}
+		}
+	}
+}
+
+// ResumePending returns the handoffs a crashed run left unresolved, so the
+// caller can pick them up before starting a fresh pipeline pass. It only
+// lists them; it does not wait on, notify about, or clear any of them.
+func (h *Handler) ResumePending() ([]*storage.PendingHandoff, error) {
+	return h.db.ListPendingHandoffs()
+}
@@ -0,0 +1,96 @@
+package handoff
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/automation-poc/browser-automation/logger"
+)
+
+// ipcServer listens on a unix socket for lines of the form
+// "handoff complete <session-id>", sent by the operator CLI, and republishes
+// the session ID on completions for Handler.wait to consume.
+type ipcServer struct {
+	listener    net.Listener
+	completions chan string
+	log         *logger.Logger
+}
+
+func newIPCServer(socketPath string, log *logger.Logger) (*ipcServer, error) {
+	_ = os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &ipcServer{
+		listener:    listener,
+		completions: make(chan string, 8),
+		log:         log,
+	}
+
+	go srv.acceptLoop()
+
+	return srv, nil
+}
+
+func (s *ipcServer) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *ipcServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != "handoff" || fields[1] != "complete" {
+			fmt.Fprintln(conn, "ERR expected: handoff complete <session-id>")
+			continue
+		}
+
+		s.completions <- fields[2]
+		fmt.Fprintln(conn, "OK")
+	}
+}
+
+func (s *ipcServer) Close() error {
+	return s.listener.Close()
+}
+
+// SendComplete dials socketPath and signals that sessionID's handoff has
+// been resolved by a human operator. This is what the
+// `automation handoff complete <session-id>` CLI invocation calls.
+func SendComplete(socketPath, sessionID string) error {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to reach handoff IPC socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "handoff complete %s\n", sessionID); err != nil {
+		return fmt.Errorf("failed to send completion signal: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read handoff ack: %w", err)
+	}
+
+	if strings.TrimSpace(reply) != "OK" {
+		return fmt.Errorf("handoff daemon rejected completion: %s", strings.TrimSpace(reply))
+	}
+
+	return nil
+}
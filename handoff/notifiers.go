@@ -0,0 +1,124 @@
+package handoff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+
+	"github.com/automation-poc/browser-automation/config"
+)
+
+// WebhookNotifier POSTs a JSON payload describing the challenge to a
+// generic HTTP endpoint.
+type WebhookNotifier struct {
+	URL string
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event ChallengeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal challenge event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SlackNotifier posts a formatted message to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, event ChallengeEvent) error {
+	text := fmt.Sprintf(":rotating_light: %s challenge detected on session %s\n%s", event.ChallengeType, event.SessionID, event.PageURL)
+
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// SMTPNotifier emails an operator through a plain SMTP relay.
+type SMTPNotifier struct {
+	cfg config.SMTPConfig
+}
+
+func NewSMTPNotifier(cfg config.SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, event ChallengeEvent) error {
+	addr := fmt.Sprintf("%s:%d", n.cfg.Host, n.cfg.Port)
+
+	subject := fmt.Sprintf("Subject: Automation handoff needed: %s\r\n", event.ChallengeType)
+	body := fmt.Sprintf("Session %s hit a %s challenge at %s (detected %s).\r\n",
+		event.SessionID, event.ChallengeType, event.PageURL, event.DetectedAt.Format("2006-01-02 15:04:05"))
+
+	msg := []byte(subject + "\r\n" + body)
+
+	if err := smtp.SendMail(addr, nil, n.cfg.From, []string{n.cfg.To}, msg); err != nil {
+		return fmt.Errorf("failed to send handoff email: %w", err)
+	}
+
+	return nil
+}
+
+// NotifiersFromConfig builds every notifier that has enough configuration
+// to actually deliver a message, skipping the rest.
+func NotifiersFromConfig(cfg *config.HandoffConfig) []Notifier {
+	var notifiers []Notifier
+
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.SlackWebhookURL))
+	}
+
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.WebhookURL))
+	}
+
+	if cfg.SMTP.Host != "" && cfg.SMTP.To != "" {
+		notifiers = append(notifiers, NewSMTPNotifier(cfg.SMTP))
+	}
+
+	return notifiers
+}
@@ -0,0 +1,91 @@
+package hooks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHookTableOrdering(t *testing.T) {
+	table := NewHookTable()
+
+	var order []int
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		order = append(order, 1)
+		return ctx, false, nil
+	})
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		order = append(order, 2)
+		return ctx, false, nil
+	})
+
+	if _, _, err := table.Fire("test_point", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Errorf("expected hooks to run in registration order, got %v", order)
+	}
+}
+
+func TestHookTableSkip(t *testing.T) {
+	table := NewHookTable()
+
+	ran := false
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		return ctx, true, nil
+	})
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		ran = true
+		return ctx, false, nil
+	})
+
+	_, skip, err := table.Fire("test_point", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !skip {
+		t.Error("expected skip=true after first hook returns skip")
+	}
+	if ran {
+		t.Error("expected second hook not to run after skip")
+	}
+}
+
+func TestHookTableAbort(t *testing.T) {
+	table := NewHookTable()
+	wantErr := errors.New("boom")
+
+	ran := false
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		return ctx, false, wantErr
+	})
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		ran = true
+		return ctx, false, nil
+	})
+
+	_, _, err := table.Fire("test_point", nil)
+	if err == nil {
+		t.Fatal("expected an error from Fire")
+	}
+	if ran {
+		t.Error("expected second hook not to run after abort")
+	}
+}
+
+func TestHookTableRewritesContext(t *testing.T) {
+	table := NewHookTable()
+
+	table.Register("test_point", func(ctx interface{}) (interface{}, bool, error) {
+		s := ctx.(string)
+		return s + "-rewritten", false, nil
+	})
+
+	result, _, err := table.Fire("test_point", "original")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.(string) != "original-rewritten" {
+		t.Errorf("expected rewritten context, got %v", result)
+	}
+}
@@ -0,0 +1,7 @@
+package hooks
+
+import "path/filepath"
+
+func pluginFiles(dir string) ([]string, error) {
+	return filepath.Glob(filepath.Join(dir, "*.so"))
+}
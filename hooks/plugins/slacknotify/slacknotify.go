@@ -0,0 +1,52 @@
+// Command slacknotify is a sample HookTable plugin that posts a message to a
+// Slack incoming webhook whenever a CAPTCHA or 2FA checkpoint is detected
+// during login.
+// Build it with:
+//
+//	go build -buildmode=plugin -o slacknotify.so ./hooks/plugins/slacknotify
+//
+// and drop the resulting .so into the directory passed to --plugin-dir.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/automation-poc/browser-automation/hooks"
+)
+
+// Register is looked up by hooks.LoadPlugins and wired into the running
+// HookTable.
+func Register(table *hooks.HookTable) {
+	table.Register(hooks.CaptchaDetected, notifySlack)
+}
+
+func notifySlack(ctx interface{}) (interface{}, bool, error) {
+	loginCtx, ok := ctx.(hooks.LoginCtx)
+	if !ok {
+		return ctx, false, nil
+	}
+
+	webhookURL := os.Getenv("SLACK_WEBHOOK_URL")
+	if webhookURL == "" {
+		return ctx, false, nil
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("Security checkpoint hit while logging in as %s", loginCtx.Email),
+	})
+	if err != nil {
+		return ctx, false, fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	resp, err := http.Post(webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return ctx, false, fmt.Errorf("failed to notify slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return ctx, false, nil
+}
@@ -0,0 +1,47 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/automation-poc/browser-automation/logger"
+	"github.com/automation-poc/browser-automation/storage"
+)
+
+// StaleProfileGCTask purges connection_requests rows that are no longer
+// pending and were sent more than retention ago, keeping long-running
+// campaigns from growing the table forever.
+type StaleProfileGCTask struct {
+	db        *storage.Database
+	log       *logger.Logger
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewStaleProfileGCTask builds a StaleProfileGCTask that purges processed
+// profiles older than retention every interval.
+func NewStaleProfileGCTask(db *storage.Database, log *logger.Logger, interval, retention time.Duration) *StaleProfileGCTask {
+	return &StaleProfileGCTask{
+		db:        db,
+		log:       log,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+func (t *StaleProfileGCTask) Name() string { return "stale_profile_gc" }
+
+func (t *StaleProfileGCTask) Interval() time.Duration { return t.interval }
+
+func (t *StaleProfileGCTask) Run(ctx context.Context) error {
+	purged, err := t.db.PurgeOldProcessedProfiles(t.retention)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		t.log.Infof("stale_profile_gc: purged %d processed profiles older than %s", purged, t.retention)
+	}
+
+	return nil
+}
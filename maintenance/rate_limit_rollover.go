@@ -0,0 +1,48 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/automation-poc/browser-automation/logger"
+	"github.com/automation-poc/browser-automation/storage"
+)
+
+// RateLimitRolloverTask prunes daily_stats/hourly_stats rows older than
+// retention on a schedule. The counters connections.RateLimiter checks are
+// keyed by date and by hour, so each one starts from zero at its wall-clock
+// boundary on its own; this task only removes the old windows' rows.
+type RateLimitRolloverTask struct {
+	db        *storage.Database
+	log       *logger.Logger
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewRateLimitRolloverTask builds a RateLimitRolloverTask that prunes stats
+// rows older than retention every interval.
+func NewRateLimitRolloverTask(db *storage.Database, log *logger.Logger, interval, retention time.Duration) *RateLimitRolloverTask {
+	return &RateLimitRolloverTask{
+		db:        db,
+		log:       log,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+func (t *RateLimitRolloverTask) Name() string { return "rate_limit_rollover" }
+
+func (t *RateLimitRolloverTask) Interval() time.Duration { return t.interval }
+
+func (t *RateLimitRolloverTask) Run(ctx context.Context) error {
+	pruned, err := t.db.PruneOldStats(t.retention)
+	if err != nil {
+		return err
+	}
+
+	if pruned > 0 {
+		t.log.Infof("rate_limit_rollover: pruned %d stale rate-limit window rows", pruned)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package maintenance runs periodic background upkeep (session refresh,
+// rate-limit window rollover, stale-row GC, selector health eviction)
+// alongside the main crawl loop, the way a long-running service runs its
+// own GC ticks rather than folding cleanup into the request path.
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/automation-poc/browser-automation/logger"
+)
+
+// Task is one unit of periodic maintenance work. Adding new background
+// upkeep (e.g. screenshot cleanup) means implementing this interface and
+// passing an instance to NewRunner - no changes to Runner itself.
+type Task interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
+
+// TaskStatus is a point-in-time snapshot of one task's last execution.
+type TaskStatus struct {
+	LastRun     time.Time
+	NextRun     time.Time
+	LastErr     error
+	LastElapsed time.Duration
+}
+
+// Status is a snapshot of every registered task, keyed by Task.Name(), for
+// later exposure over a status endpoint.
+type Status struct {
+	Tasks map[string]TaskStatus
+}
+
+// Runner ticks each registered Task on its own interval until its context
+// is cancelled.
+type Runner struct {
+	log   *logger.Logger
+	tasks []Task
+
+	mu     sync.RWMutex
+	status map[string]TaskStatus
+}
+
+// NewRunner builds a Runner over tasks. It does not start anything until
+// Start is called.
+func NewRunner(log *logger.Logger, tasks ...Task) *Runner {
+	return &Runner{
+		log:    log,
+		tasks:  tasks,
+		status: make(map[string]TaskStatus, len(tasks)),
+	}
+}
+
+// Start launches one goroutine per task and returns immediately. Every
+// goroutine exits once ctx is cancelled, so the caller's existing SIGTERM
+// handling shuts maintenance down along with everything else.
+func (r *Runner) Start(ctx context.Context) {
+	for _, task := range r.tasks {
+		r.mu.Lock()
+		r.status[task.Name()] = TaskStatus{NextRun: time.Now().Add(task.Interval())}
+		r.mu.Unlock()
+
+		go r.runLoop(ctx, task)
+	}
+}
+
+func (r *Runner) runLoop(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.runOnce(ctx, task)
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context, task Task) {
+	start := time.Now()
+	err := task.Run(ctx)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		r.log.Warnf("maintenance task %s failed after %s: %v", task.Name(), elapsed, err)
+	} else {
+		r.log.Debugf("maintenance task %s completed in %s", task.Name(), elapsed)
+	}
+
+	r.mu.Lock()
+	r.status[task.Name()] = TaskStatus{
+		LastRun:     start,
+		NextRun:     start.Add(task.Interval()),
+		LastErr:     err,
+		LastElapsed: elapsed,
+	}
+	r.mu.Unlock()
+}
+
+// Status returns a snapshot safe to read concurrently with running tasks.
+func (r *Runner) Status() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make(map[string]TaskStatus, len(r.status))
+	for name, s := range r.status {
+		tasks[name] = s
+	}
+
+	return Status{Tasks: tasks}
+}
@@ -0,0 +1,50 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/automation-poc/browser-automation/logger"
+	"github.com/automation-poc/browser-automation/selectorhealth"
+)
+
+// MissRateThreshold is the fraction of misses at or above which a selector
+// is considered unhealthy and evicted.
+const MissRateThreshold = 0.5
+
+// MinSelectorAttempts is the minimum number of recorded lookups a selector
+// needs before its miss rate is trusted enough to evict on.
+const MinSelectorAttempts = 10
+
+// SelectorHealthTask evicts selectors from a selectorhealth.Registry once
+// they've started returning has=false too frequently, logging them so an
+// operator can refresh the config selector before it fails outright.
+type SelectorHealthTask struct {
+	registry *selectorhealth.Registry
+	log      *logger.Logger
+	interval time.Duration
+}
+
+// NewSelectorHealthTask builds a SelectorHealthTask that sweeps registry
+// every interval.
+func NewSelectorHealthTask(registry *selectorhealth.Registry, log *logger.Logger, interval time.Duration) *SelectorHealthTask {
+	return &SelectorHealthTask{
+		registry: registry,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (t *SelectorHealthTask) Name() string { return "selector_health" }
+
+func (t *SelectorHealthTask) Interval() time.Duration { return t.interval }
+
+func (t *SelectorHealthTask) Run(ctx context.Context) error {
+	evicted := t.registry.Evict(MissRateThreshold, MinSelectorAttempts)
+
+	if len(evicted) > 0 {
+		t.log.Warnf("selector_health: evicted %d unhealthy selector(s): %v", len(evicted), evicted)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/automation-poc/browser-automation/auth"
+	"github.com/automation-poc/browser-automation/logger"
+	"github.com/go-rod/rod"
+)
+
+// SessionRefreshTask revalidates the current session on every tick, firing
+// a re-login through auth.Service.Login once auth.Service.IsLoggedIn
+// reports the session has gone stale. It does not look at cookie expiry
+// times, so it re-logs in only after the session has already lapsed.
+type SessionRefreshTask struct {
+	auth     *auth.Service
+	page     *rod.Page
+	log      *logger.Logger
+	interval time.Duration
+}
+
+// NewSessionRefreshTask builds a SessionRefreshTask that checks page's
+// login state every interval.
+func NewSessionRefreshTask(authService *auth.Service, page *rod.Page, log *logger.Logger, interval time.Duration) *SessionRefreshTask {
+	return &SessionRefreshTask{
+		auth:     authService,
+		page:     page,
+		log:      log,
+		interval: interval,
+	}
+}
+
+func (t *SessionRefreshTask) Name() string { return "session_refresh" }
+
+func (t *SessionRefreshTask) Interval() time.Duration { return t.interval }
+
+func (t *SessionRefreshTask) Run(ctx context.Context) error {
+	if t.auth.IsLoggedIn(t.page) {
+		return nil
+	}
+
+	t.log.Warn("session_refresh: session no longer valid, triggering proactive re-login")
+	return t.auth.Login(ctx, t.page)
+}
@@ -1,98 +1,189 @@
 package messaging
 
 import (
+	"bytes"
 	"fmt"
+	"math/rand"
 	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode"
 
 	"github.com/automation-poc/browser-automation/storage"
 )
 
+// Template is a text/template body plus metadata used to select and
+// validate it. Variables is derived from the parsed AST rather than
+// hand-maintained, so it always reflects what the template actually
+// references.
 type Template struct {
-	Name    string
-	Content string
+	Name      string
+	Content   string
+	MaxChars  int
 	Variables []string
+
+	parsed *template.Template
+}
+
+// TemplateData is what a Template body is rendered against. Fields are
+// exported so templates can reference them with dot notation, e.g.
+// {{.Name}} or {{if .Company}}...{{end}}.
+type TemplateData struct {
+	Name    string
+	Title   string
+	Company string
+	Profile ProfileData
+	Tags    map[string]string
+}
+
+// ProfileData carries the profile details that don't warrant their own
+// top-level TemplateData field.
+type ProfileData struct {
+	Headline string
+	Location string
 }
 
 var DefaultTemplates = []Template{
 	{
-		Name:    "follow_up_general",
-		Content: "Hi {{name}}, thanks for connecting! I'd love to learn more about your work at {{company}}. Are you available for a quick chat sometime?",
-		Variables: []string{"name", "company"},
+		Name:     "follow_up_general",
+		Content:  "Hi {{firstName .Name}}, thanks for connecting! I'd love to learn more about {{if .Company}}your work at {{.Company}}{{else}}what you're building{{end}}. Are you available for a quick chat sometime?",
+		MaxChars: 300,
 	},
 	{
-		Name:    "follow_up_tech",
-		Content: "Hello {{name}}, great to connect! I'm really interested in {{title}} roles and would appreciate any insights you might share about your experience at {{company}}.",
-		Variables: []string{"name", "title", "company"},
+		Name:     "follow_up_tech",
+		Content:  "Hello {{firstName .Name}}, great to connect! I'm really interested in {{default \"roles like yours\" .Title}} and would appreciate any insights you might share about your experience at {{default \"your company\" .Company}}.",
+		MaxChars: 300,
 	},
 	{
-		Name:    "follow_up_collaboration",
-		Content: "Hi {{name}}, thanks for accepting my connection request! I noticed we have similar professional interests. I'd love to explore potential collaboration opportunities.",
-		Variables: []string{"name"},
+		Name:     "follow_up_collaboration",
+		Content:  "Hi {{firstName .Name}}, thanks for accepting my connection request! I noticed we have similar professional interests. I'd love to explore potential collaboration opportunities.",
+		MaxChars: 300,
 	},
 	{
-		Name:    "follow_up_learning",
-		Content: "Hello {{name}}, I appreciate you connecting! Your background in {{title}} is impressive. I'm currently exploring this field and would value any advice you're willing to share.",
-		Variables: []string{"name", "title"},
+		Name:     "follow_up_learning",
+		Content:  "Hello {{firstName .Name}}, I appreciate you connecting! Your background in {{default \"your field\" .Title}} is impressive. I'm currently exploring this field and would value any advice you're willing to share.",
+		MaxChars: 300,
 	},
 }
 
 type TemplateEngine struct {
 	templates map[string]Template
+	random    *rand.Rand
 }
 
 func NewTemplateEngine() *TemplateEngine {
-	engine := &TemplateEngine{
+	return newTemplateEngine(rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// NewSeededTemplateEngine builds an engine whose pickRandom func draws from
+// a deterministic source, for use in tests.
+func NewSeededTemplateEngine(seed int64) *TemplateEngine {
+	return newTemplateEngine(rand.New(rand.NewSource(seed)))
+}
+
+func newTemplateEngine(source *rand.Rand) *TemplateEngine {
+	te := &TemplateEngine{
 		templates: make(map[string]Template),
+		random:    source,
+	}
+
+	for _, t := range DefaultTemplates {
+		if err := te.AddTemplate(t); err != nil {
+			panic(fmt.Sprintf("invalid default template %q: %v", t.Name, err))
+		}
 	}
 
-	for _, template := range DefaultTemplates {
-		engine.templates[template.Name] = template
+	return te
+}
+
+func (te *TemplateEngine) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"firstName": extractFirstName,
+		"titleCase": titleCase,
+		"truncate":  truncateWords,
+		"default": func(fallback, val string) string {
+			if strings.TrimSpace(val) == "" {
+				return fallback
+			}
+			return val
+		},
+		"weekday":    func() string { return time.Now().Weekday().String() },
+		"pickRandom": func(options ...string) string { return te.pickRandom(options) },
 	}
+}
 
-	return engine
+func (te *TemplateEngine) pickRandom(options []string) string {
+	if len(options) == 0 {
+		return ""
+	}
+	return options[te.random.Intn(len(options))]
 }
 
-func (te *TemplateEngine) AddTemplate(template Template) {
-	te.templates[template.Name] = template
+// AddTemplate parses the template's content, computes its Variables from
+// the resulting AST, and registers it. It returns an error if the content
+// fails to parse or references a field TemplateData doesn't have.
+func (te *TemplateEngine) AddTemplate(t Template) error {
+	parsed, err := template.New(t.Name).Funcs(te.funcMap()).Parse(t.Content)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %q: %w", t.Name, err)
+	}
+
+	t.Variables = referencedFields(parsed)
+	t.parsed = parsed
+
+	if err := validateFields(t.Variables); err != nil {
+		return fmt.Errorf("template %q: %w", t.Name, err)
+	}
+
+	te.templates[t.Name] = t
+	return nil
 }
 
 func (te *TemplateEngine) GetTemplate(name string) (Template, error) {
-	template, exists := te.templates[name]
+	t, exists := te.templates[name]
 	if !exists {
 		return Template{}, fmt.Errorf("template not found: %s", name)
 	}
-
-	return template, nil
+	return t, nil
 }
 
-func (te *TemplateEngine) RenderTemplate(templateName string, variables map[string]string) (string, error) {
-	template, err := te.GetTemplate(templateName)
+// RenderTemplate executes the named template against data, then applies
+// the template's MaxChars limit if set.
+func (te *TemplateEngine) RenderTemplate(templateName string, data TemplateData) (string, error) {
+	t, err := te.GetTemplate(templateName)
 	if err != nil {
 		return "", err
 	}
 
-	content := template.Content
-
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		content = strings.ReplaceAll(content, placeholder, value)
+	var buf bytes.Buffer
+	if err := t.parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", templateName, err)
 	}
 
-	if strings.Contains(content, "{{") {
-		return content, fmt.Errorf("warning: unresolved variables in template")
+	rendered := buf.String()
+	if t.MaxChars > 0 && len(rendered) > t.MaxChars {
+		rendered = truncateAtSentence(rendered, t.MaxChars)
 	}
 
-	return content, nil
+	return rendered, nil
 }
 
+// RenderForConnection builds TemplateData from a stored connection and
+// renders the named template against it.
 func (te *TemplateEngine) RenderForConnection(conn *storage.ConnectionRequest, templateName string) (string, error) {
-	variables := map[string]string{
-		"name":    extractFirstName(conn.Name),
-		"title":   conn.Title,
-		"company": conn.Company,
+	data := TemplateData{
+		Name:    conn.Name,
+		Title:   conn.Title,
+		Company: conn.Company,
+		Profile: ProfileData{
+			Headline: conn.Headline,
+			Location: conn.Location,
+		},
+		Tags: conn.Tags,
 	}
 
-	return te.RenderTemplate(templateName, variables)
+	return te.RenderTemplate(templateName, data)
 }
 
 func extractFirstName(fullName string) string {
@@ -100,12 +191,63 @@ func extractFirstName(fullName string) string {
 		return "there"
 	}
 
-	parts := strings.Split(fullName, " ")
-	if len(parts) > 0 {
-		return parts[0]
+	parts := strings.SplitN(strings.TrimSpace(fullName), " ", 2)
+	return parts[0]
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
+
+func truncateWords(n int, s string) string {
+	words := strings.Fields(s)
+	if len(words) <= n {
+		return s
+	}
+	return strings.Join(words[:n], " ") + "..."
+}
+
+// truncateAtSentence shortens s to fit within max characters, preferring to
+// cut at the last sentence boundary (. ! ?) and falling back to the last
+// word boundary, so a LinkedIn-style character cap doesn't end mid-word.
+func truncateAtSentence(s string, max int) string {
+	if len(s) <= max {
+		return s
 	}
 
-	return fullName
+	window := s[:max]
+
+	if idx := lastSentenceEnd(window); idx > 0 {
+		return strings.TrimSpace(window[:idx+1])
+	}
+
+	const ellipsis = "..."
+	if max <= len(ellipsis) {
+		return window
+	}
+	window = s[:max-len(ellipsis)]
+
+	if idx := strings.LastIndexByte(window, ' '); idx > 0 {
+		return strings.TrimSpace(window[:idx]) + ellipsis
+	}
+
+	return strings.TrimSpace(window) + ellipsis
+}
+
+func lastSentenceEnd(s string) int {
+	last := -1
+	for i, r := range s {
+		if r == '.' || r == '!' || r == '?' {
+			last = i
+		}
+	}
+	return last
 }
 
 func (te *TemplateEngine) SelectBestTemplate(conn *storage.ConnectionRequest) string {
@@ -125,28 +267,110 @@ func (te *TemplateEngine) SelectBestTemplate(conn *storage.ConnectionRequest) st
 }
 
 func (te *TemplateEngine) ListTemplates() []string {
-	var names []string
+	names := make([]string, 0, len(te.templates))
 	for name := range te.templates {
 		names = append(names, name)
 	}
 	return names
 }
 
-func (te *TemplateEngine) ValidateVariables(templateName string, variables map[string]string) error {
-	template, err := te.GetTemplate(templateName)
+// ValidateVariables reports whether every field the named template
+// references actually exists on TemplateData, catching typos like
+// {{.Compnay}} at load time instead of at render time.
+func (te *TemplateEngine) ValidateVariables(templateName string) error {
+	t, err := te.GetTemplate(templateName)
 	if err != nil {
 		return err
 	}
 
-	missingVars := []string{}
-	for _, requiredVar := range template.Variables {
-		if _, exists := variables[requiredVar]; !exists {
-			missingVars = append(missingVars, requiredVar)
+	return validateFields(t.Variables)
+}
+
+// referencedFields walks a parsed template's AST and returns the dotted
+// field paths it references, e.g. "Company" or "Profile.Headline".
+func referencedFields(t *template.Template) []string {
+	seen := make(map[string]bool)
+	walkNode(t.Root, seen)
+
+	fields := make([]string, 0, len(seen))
+	for f := range seen {
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func walkNode(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkNode(child, seen)
 		}
+	case *parse.ActionNode:
+		walkPipe(n.Pipe, seen)
+	case *parse.IfNode:
+		walkPipe(n.Pipe, seen)
+		walkNode(n.List, seen)
+		walkNode(n.ElseList, seen)
+	case *parse.RangeNode:
+		walkPipe(n.Pipe, seen)
+		walkNode(n.List, seen)
+		walkNode(n.ElseList, seen)
+	case *parse.WithNode:
+		walkPipe(n.Pipe, seen)
+		walkNode(n.List, seen)
+		walkNode(n.ElseList, seen)
+	case *parse.TemplateNode:
+		walkPipe(n.Pipe, seen)
+	}
+}
+
+func walkPipe(pipe *parse.PipeNode, seen map[string]bool) {
+	if pipe == nil {
+		return
 	}
 
-	if len(missingVars) > 0 {
-		return fmt.Errorf("missing required variables: %v", missingVars)
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				seen[strings.Join(a.Ident, ".")] = true
+			case *parse.PipeNode:
+				walkPipe(a, seen)
+			}
+		}
+	}
+}
+
+// validFieldRoots enumerates TemplateData's top-level fields. Deeper
+// segments under Profile are checked structurally; segments under Tags are
+// runtime map keys and can't be validated statically.
+var validFieldRoots = map[string]bool{
+	"Name":    true,
+	"Title":   true,
+	"Company": true,
+	"Profile": true,
+	"Tags":    true,
+}
+
+var validProfileFields = map[string]bool{
+	"Headline": true,
+	"Location": true,
+}
+
+func validateFields(fields []string) error {
+	for _, f := range fields {
+		parts := strings.Split(f, ".")
+
+		if !validFieldRoots[parts[0]] {
+			return fmt.Errorf("unknown template field %q", f)
+		}
+
+		if parts[0] == "Profile" && len(parts) > 1 && !validProfileFields[parts[1]] {
+			return fmt.Errorf("unknown template field %q", f)
+		}
 	}
 
 	return nil
@@ -0,0 +1,182 @@
+package messaging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/automation-poc/browser-automation/storage"
+)
+
+func TestNewTemplateEngineRegistersDefaults(t *testing.T) {
+	te := NewTemplateEngine()
+
+	for _, tmpl := range DefaultTemplates {
+		if _, err := te.GetTemplate(tmpl.Name); err != nil {
+			t.Errorf("expected default template %q to be registered: %v", tmpl.Name, err)
+		}
+	}
+}
+
+func TestSelectBestTemplateUnchanged(t *testing.T) {
+	te := NewTemplateEngine()
+
+	tests := []struct {
+		name    string
+		conn    *storage.ConnectionRequest
+		wantTpl string
+	}{
+		{"title and company", &storage.ConnectionRequest{Name: "Alice Smith", Title: "Engineer", Company: "Acme"}, "follow_up_tech"},
+		{"company only", &storage.ConnectionRequest{Name: "Bob Jones", Company: "Acme"}, "follow_up_general"},
+		{"title only", &storage.ConnectionRequest{Name: "Carol Lee", Title: "Engineer"}, "follow_up_learning"},
+		{"neither", &storage.ConnectionRequest{Name: "Dan Kim"}, "follow_up_collaboration"},
+	}
+
+	for _, tt := range tests {
+		if got := te.SelectBestTemplate(tt.conn); got != tt.wantTpl {
+			t.Errorf("%s: SelectBestTemplate() = %q, want %q", tt.name, got, tt.wantTpl)
+		}
+	}
+}
+
+// TestDefaultTemplatesRenderForConnection is a migration test: it checks
+// the four DefaultTemplates still render sensible, fully-resolved messages
+// for the connection shapes SelectBestTemplate routes to them, now that
+// rendering goes through text/template instead of strings.ReplaceAll.
+func TestDefaultTemplatesRenderForConnection(t *testing.T) {
+	te := NewTemplateEngine()
+
+	tests := []struct {
+		templateName string
+		conn         *storage.ConnectionRequest
+		wantContains []string
+	}{
+		{
+			templateName: "follow_up_general",
+			conn:         &storage.ConnectionRequest{Name: "Alice Smith", Company: "Acme"},
+			wantContains: []string{"Hi Alice", "your work at Acme"},
+		},
+		{
+			templateName: "follow_up_general",
+			conn:         &storage.ConnectionRequest{Name: "Alice Smith"},
+			wantContains: []string{"Hi Alice", "what you're building"},
+		},
+		{
+			templateName: "follow_up_tech",
+			conn:         &storage.ConnectionRequest{Name: "Bob Jones", Title: "Engineer", Company: "Acme"},
+			wantContains: []string{"Hello Bob", "Engineer", "Acme"},
+		},
+		{
+			templateName: "follow_up_collaboration",
+			conn:         &storage.ConnectionRequest{Name: "Carol Lee"},
+			wantContains: []string{"Hi Carol", "collaboration opportunities"},
+		},
+		{
+			templateName: "follow_up_learning",
+			conn:         &storage.ConnectionRequest{Name: "Dan Kim", Title: "Designer"},
+			wantContains: []string{"Hello Dan", "Designer"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := te.RenderForConnection(tt.conn, tt.templateName)
+		if err != nil {
+			t.Fatalf("RenderForConnection(%q) returned error: %v", tt.templateName, err)
+		}
+
+		if strings.Contains(got, "{{") {
+			t.Errorf("RenderForConnection(%q) left unresolved template syntax: %q", tt.templateName, got)
+		}
+
+		for _, want := range tt.wantContains {
+			if !strings.Contains(got, want) {
+				t.Errorf("RenderForConnection(%q) = %q, want it to contain %q", tt.templateName, got, want)
+			}
+		}
+	}
+}
+
+func TestRenderForConnectionExposesProfileAndTags(t *testing.T) {
+	te := NewTemplateEngine()
+	if err := te.AddTemplate(Template{
+		Name:    "profile_aware",
+		Content: "{{firstName .Name}} works as {{.Profile.Headline}} in {{.Profile.Location}} (vip={{.Tags.vip}})",
+	}); err != nil {
+		t.Fatalf("AddTemplate() returned error: %v", err)
+	}
+
+	conn := &storage.ConnectionRequest{
+		Name:     "Erin Doe",
+		Headline: "Staff Engineer",
+		Location: "Remote",
+		Tags:     map[string]string{"vip": "true"},
+	}
+
+	got, err := te.RenderForConnection(conn, "profile_aware")
+	if err != nil {
+		t.Fatalf("RenderForConnection() returned error: %v", err)
+	}
+
+	want := "Erin works as Staff Engineer in Remote (vip=true)"
+	if got != want {
+		t.Errorf("RenderForConnection() = %q, want %q", got, want)
+	}
+}
+
+func TestAddTemplateRejectsUnknownField(t *testing.T) {
+	te := NewTemplateEngine()
+
+	err := te.AddTemplate(Template{
+		Name:    "typo",
+		Content: "Hi {{.Compnay}}",
+	})
+
+	if err == nil {
+		t.Fatal("expected AddTemplate to reject a misspelled field, got nil error")
+	}
+}
+
+func TestMaxCharsTruncatesAtSentenceBoundary(t *testing.T) {
+	te := NewTemplateEngine()
+
+	if err := te.AddTemplate(Template{
+		Name:     "long",
+		Content:  "First sentence here. Second sentence that pushes well past the limit and should be dropped entirely.",
+		MaxChars: 25,
+	}); err != nil {
+		t.Fatalf("AddTemplate() returned error: %v", err)
+	}
+
+	got, err := te.RenderTemplate("long", TemplateData{})
+	if err != nil {
+		t.Fatalf("RenderTemplate() returned error: %v", err)
+	}
+
+	if got != "First sentence here." {
+		t.Errorf("RenderTemplate() = %q, want %q", got, "First sentence here.")
+	}
+}
+
+func TestPickRandomIsDeterministicWhenSeeded(t *testing.T) {
+	a := NewSeededTemplateEngine(42)
+	b := NewSeededTemplateEngine(42)
+
+	if err := a.AddTemplate(Template{Name: "greet", Content: `{{pickRandom "Hi" "Hey" "Hello"}} there`}); err != nil {
+		t.Fatalf("AddTemplate() returned error: %v", err)
+	}
+	if err := b.AddTemplate(Template{Name: "greet", Content: `{{pickRandom "Hi" "Hey" "Hello"}} there`}); err != nil {
+		t.Fatalf("AddTemplate() returned error: %v", err)
+	}
+
+	gotA, err := a.RenderTemplate("greet", TemplateData{})
+	if err != nil {
+		t.Fatalf("RenderTemplate() returned error: %v", err)
+	}
+	gotB, err := b.RenderTemplate("greet", TemplateData{})
+	if err != nil {
+		t.Fatalf("RenderTemplate() returned error: %v", err)
+	}
+
+	if gotA != gotB {
+		t.Errorf("expected identically-seeded engines to pick the same option, got %q and %q", gotA, gotB)
+	}
+}
@@ -0,0 +1,82 @@
+// Package selectorhealth tracks how often hard-coded DOM selectors (today,
+// the connect-button selectors in connections) actually find an element,
+// so a maintenance task can flag or evict ones that have started failing most of the time - an
+// early signal that a site's markup changed. This is deliberately minimal;
+// it's a stopgap ahead of a proper adaptive multi-selector resolver.
+package selectorhealth
+
+import "sync"
+
+// stats is the running hit/miss count for one selector.
+type stats struct {
+	attempts int
+	misses   int
+}
+
+// Registry is a concurrency-safe selector -> stats table.
+type Registry struct {
+	mu    sync.Mutex
+	stats map[string]*stats
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{stats: make(map[string]*stats)}
+}
+
+// Default is the process-wide registry that connections records into when
+// it probes a connect-button selector with page.Has.
+var Default = New()
+
+// Record notes one lookup of selector, found or not.
+func (r *Registry) Record(selector string, found bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, exists := r.stats[selector]
+	if !exists {
+		s = &stats{}
+		r.stats[selector] = s
+	}
+
+	s.attempts++
+	if !found {
+		s.misses++
+	}
+}
+
+// MissRate returns the fraction of recorded lookups that missed, or 0 if
+// the selector has no recorded attempts.
+func (r *Registry) MissRate(selector string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, exists := r.stats[selector]
+	if !exists || s.attempts == 0 {
+		return 0
+	}
+
+	return float64(s.misses) / float64(s.attempts)
+}
+
+// Evict resets the stats for every selector whose miss rate is at or above
+// threshold, provided it has at least minAttempts recorded, and returns
+// their names so the caller can log or alert on them.
+func (r *Registry) Evict(threshold float64, minAttempts int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var evicted []string
+	for selector, s := range r.stats {
+		if s.attempts < minAttempts {
+			continue
+		}
+
+		if float64(s.misses)/float64(s.attempts) >= threshold {
+			evicted = append(evicted, selector)
+			delete(r.stats, selector)
+		}
+	}
+
+	return evicted
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PendingHandoff records a CAPTCHA/2FA challenge that is waiting on a human
+// operator, so a crash mid-handoff can be resumed on the next run.
+type PendingHandoff struct {
+	SessionID     string
+	ChallengeType string
+	PageURL       string
+	DetectedAt    time.Time
+	Resolved      bool
+}
+
+func (d *Database) SavePendingHandoff(h *PendingHandoff) error {
+	query := `
+		INSERT INTO pending_handoffs (session_id, challenge_type, page_url, resolved)
+		VALUES (?, ?, ?, 0)
+		ON CONFLICT(session_id) DO UPDATE SET
+			challenge_type = excluded.challenge_type,
+			page_url = excluded.page_url,
+			resolved = 0
+	`
+
+	_, err := d.db.Exec(query, h.SessionID, h.ChallengeType, h.PageURL)
+	return err
+}
+
+func (d *Database) GetPendingHandoff(sessionID string) (*PendingHandoff, error) {
+	query := `
+		SELECT session_id, challenge_type, page_url, detected_at, resolved
+		FROM pending_handoffs
+		WHERE session_id = ? AND resolved = 0
+	`
+
+	var h PendingHandoff
+	var resolved int
+	err := d.db.QueryRow(query, sessionID).Scan(&h.SessionID, &h.ChallengeType, &h.PageURL, &h.DetectedAt, &resolved)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h.Resolved = resolved != 0
+	return &h, nil
+}
+
+func (d *Database) ResolvePendingHandoff(sessionID string) error {
+	_, err := d.db.Exec(`UPDATE pending_handoffs SET resolved = 1 WHERE session_id = ?`, sessionID)
+	return err
+}
+
+func (d *Database) ListPendingHandoffs() ([]*PendingHandoff, error) {
+	rows, err := d.db.Query(`
+		SELECT session_id, challenge_type, page_url, detected_at, resolved
+		FROM pending_handoffs
+		WHERE resolved = 0
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var handoffs []*PendingHandoff
+	for rows.Next() {
+		var h PendingHandoff
+		var resolved int
+		if err := rows.Scan(&h.SessionID, &h.ChallengeType, &h.PageURL, &h.DetectedAt, &resolved); err != nil {
+			return nil, err
+		}
+		h.Resolved = resolved != 0
+		handoffs = append(handoffs, &h)
+	}
+
+	return handoffs, nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -18,6 +19,9 @@ type ConnectionRequest struct {
 	Name          string
 	Title         string
 	Company       string
+	Headline      string
+	Location      string
+	Tags          map[string]string
 	Message       string
 	Status        string
 	SentAt        time.Time
@@ -35,11 +39,16 @@ type Message struct {
 type DailyStats struct {
 	Date             string
 	ConnectionsSent  int
-	MessagesS sent    int
+	MessagesSent     int
 	ConnectionsLimit int
 	MessagesLimit    int
 }
 
+type HourlyStats struct {
+	Hour            string
+	ConnectionsSent int
+}
+
 func NewDatabase(path string) (*Database, error) {
 	db, err := sql.Open("sqlite3", path)
 	if err != nil {
@@ -67,6 +76,9 @@ func (d *Database) initSchema() error {
 		name TEXT NOT NULL,
 		title TEXT,
 		company TEXT,
+		headline TEXT,
+		location TEXT,
+		tags TEXT,
 		message TEXT,
 		status TEXT DEFAULT 'pending',
 		sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -96,6 +108,19 @@ func (d *Database) initSchema() error {
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	CREATE TABLE IF NOT EXISTS pending_handoffs (
+		session_id TEXT PRIMARY KEY,
+		challenge_type TEXT NOT NULL,
+		page_url TEXT,
+		detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		resolved INTEGER DEFAULT 0
+	);
+
+	CREATE TABLE IF NOT EXISTS hourly_stats (
+		hour TEXT PRIMARY KEY,
+		connections_sent INTEGER DEFAULT 0
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_connection_status ON connection_requests(status);
 	CREATE INDEX IF NOT EXISTS idx_connection_sent_at ON connection_requests(sent_at);
 	CREATE INDEX IF NOT EXISTS idx_messages_profile ON messages(profile_id);
@@ -107,31 +132,40 @@ func (d *Database) initSchema() error {
 }
 
 func (d *Database) SaveConnectionRequest(req *ConnectionRequest) error {
+	tagsJSON, err := marshalTags(req.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
 	query := `
-		INSERT INTO connection_requests (profile_id, name, title, company, message, status)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO connection_requests (profile_id, name, title, company, headline, location, tags, message, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(profile_id) DO UPDATE SET
 			name = excluded.name,
 			title = excluded.title,
 			company = excluded.company,
+			headline = excluded.headline,
+			location = excluded.location,
+			tags = excluded.tags,
 			message = excluded.message,
 			status = excluded.status
 	`
 
-	_, err := d.db.Exec(query, req.ProfileID, req.Name, req.Title, req.Company, req.Message, req.Status)
+	_, err = d.db.Exec(query, req.ProfileID, req.Name, req.Title, req.Company, req.Headline, req.Location, tagsJSON, req.Message, req.Status)
 	return err
 }
 
 func (d *Database) GetConnectionRequest(profileID string) (*ConnectionRequest, error) {
 	query := `
-		SELECT id, profile_id, name, title, company, message, status, sent_at, accepted_at, last_message_at
+		SELECT id, profile_id, name, title, company, headline, location, tags, message, status, sent_at, accepted_at, last_message_at
 		FROM connection_requests
 		WHERE profile_id = ?
 	`
 
 	var req ConnectionRequest
+	var tagsJSON sql.NullString
 	err := d.db.QueryRow(query, profileID).Scan(
-		&req.ID, &req.ProfileID, &req.Name, &req.Title, &req.Company,
+		&req.ID, &req.ProfileID, &req.Name, &req.Title, &req.Company, &req.Headline, &req.Location, &tagsJSON,
 		&req.Message, &req.Status, &req.SentAt, &req.AcceptedAt, &req.LastMessageAt,
 	)
 
@@ -143,9 +177,39 @@ func (d *Database) GetConnectionRequest(profileID string) (*ConnectionRequest, e
 		return nil, err
 	}
 
+	if req.Tags, err = unmarshalTags(tagsJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+
 	return &req, nil
 }
 
+func marshalTags(tags map[string]string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func unmarshalTags(tagsJSON sql.NullString) (map[string]string, error) {
+	if !tagsJSON.Valid || tagsJSON.String == "" {
+		return nil, nil
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal([]byte(tagsJSON.String), &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
 func (d *Database) HasProcessedProfile(profileID string) bool {
 	var count int
 	query := `SELECT COUNT(*) FROM connection_requests WHERE profile_id = ?`
@@ -155,7 +219,7 @@ func (d *Database) HasProcessedProfile(profileID string) bool {
 
 func (d *Database) GetAcceptedConnections() ([]*ConnectionRequest, error) {
 	query := `
-		SELECT id, profile_id, name, title, company, message, status, sent_at, accepted_at, last_message_at
+		SELECT id, profile_id, name, title, company, headline, location, tags, message, status, sent_at, accepted_at, last_message_at
 		FROM connection_requests
 		WHERE status = 'accepted' AND last_message_at IS NULL
 		ORDER BY accepted_at DESC
@@ -170,13 +234,17 @@ func (d *Database) GetAcceptedConnections() ([]*ConnectionRequest, error) {
 	var connections []*ConnectionRequest
 	for rows.Next() {
 		var req ConnectionRequest
+		var tagsJSON sql.NullString
 		err := rows.Scan(
-			&req.ID, &req.ProfileID, &req.Name, &req.Title, &req.Company,
+			&req.ID, &req.ProfileID, &req.Name, &req.Title, &req.Company, &req.Headline, &req.Location, &tagsJSON,
 			&req.Message, &req.Status, &req.SentAt, &req.AcceptedAt, &req.LastMessageAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if req.Tags, err = unmarshalTags(tagsJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
 		connections = append(connections, &req)
 	}
 
@@ -254,6 +322,85 @@ func (d *Database) IncrementMessageCount() error {
 	return err
 }
 
+func (d *Database) GetCurrentHourStats() (*HourlyStats, error) {
+	hour := time.Now().Format("2006-01-02T15")
+
+	query := `SELECT hour, connections_sent FROM hourly_stats WHERE hour = ?`
+
+	var stats HourlyStats
+	err := d.db.QueryRow(query, hour).Scan(&stats.Hour, &stats.ConnectionsSent)
+
+	if err == sql.ErrNoRows {
+		return &HourlyStats{Hour: hour, ConnectionsSent: 0}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (d *Database) IncrementHourlyConnectionCount() error {
+	hour := time.Now().Format("2006-01-02T15")
+
+	query := `
+		INSERT INTO hourly_stats (hour, connections_sent)
+		VALUES (?, 1)
+		ON CONFLICT(hour) DO UPDATE SET connections_sent = connections_sent + 1
+	`
+
+	_, err := d.db.Exec(query, hour)
+	return err
+}
+
+// PruneOldStats deletes daily_stats and hourly_stats rows older than
+// olderThan, keeping the two tables from growing without bound now that a
+// maintenance.Runner task calls it on a schedule instead of never.
+func (d *Database) PruneOldStats(olderThan time.Duration) (int64, error) {
+	cutoffDay := time.Now().Add(-olderThan).Format("2006-01-02")
+	cutoffHour := time.Now().Add(-olderThan).Format("2006-01-02T15")
+
+	var total int64
+
+	res, err := d.db.Exec(`DELETE FROM daily_stats WHERE date < ?`, cutoffDay)
+	if err != nil {
+		return total, err
+	}
+	if n, err := res.RowsAffected(); err == nil {
+		total += n
+	}
+
+	res, err = d.db.Exec(`DELETE FROM hourly_stats WHERE hour < ?`, cutoffHour)
+	if err != nil {
+		return total, err
+	}
+	if n, err := res.RowsAffected(); err == nil {
+		total += n
+	}
+
+	return total, nil
+}
+
+// PurgeOldProcessedProfiles deletes connection_requests rows whose status is
+// no longer 'pending' and whose sent_at is more than olderThan ago, so the
+// table doesn't grow forever over long-running campaigns.
+func (d *Database) PurgeOldProcessedProfiles(olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	query := `
+		DELETE FROM connection_requests
+		WHERE sent_at < ? AND status != 'pending'
+	`
+
+	res, err := d.db.Exec(query, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
 func (d *Database) SaveSessionState(key, value string) error {
 	query := `
 		INSERT INTO session_state (key, value, updated_at)